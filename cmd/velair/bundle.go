@@ -0,0 +1,286 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bakins/velair"
+)
+
+func supportBundle(args []string) error {
+	fs := flag.NewFlagSet("support-bundle", flag.ContinueOnError)
+	device := fs.String("device", "", "base URL of the unit, for example http://192.168.1.10")
+	output := fs.String("output", "", "archive to write (default velair-support-<time>.tar.gz)")
+	samples := fs.Int("samples", 3, "number of status payloads to collect")
+	interval := fs.Duration("interval", 2*time.Second, "time between status samples")
+	timeout := fs.Duration("timeout", 10*time.Second, "timeout for each request")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *device == "" {
+		return errors.New("--device is required")
+	}
+
+	if *samples < 1 {
+		return errors.New("--samples must be at least 1")
+	}
+
+	now := time.Now()
+
+	if *output == "" {
+		*output = "velair-support-" + now.UTC().Format("20060102T150405Z") + ".tar.gz"
+	}
+
+	rec := &recordingDoer{
+		doer: &http.Client{Timeout: *timeout},
+	}
+
+	// the bundle must never change the unit's settings.
+	client, err := velair.New(*device, velair.WithDoer(rec), velair.WithReadOnly())
+	if err != nil {
+		return err
+	}
+
+	var parsed []sample
+
+	for i := 0; i < *samples; i++ {
+		if i > 0 {
+			time.Sleep(*interval)
+		}
+
+		status, err := client.GetStatus(context.Background())
+
+		s := sample{
+			Time:   time.Now(),
+			Status: status,
+		}
+		if err != nil {
+			s.Error = err.Error()
+		}
+
+		parsed = append(parsed, s)
+	}
+
+	b := newBundle()
+
+	if err := b.addJSON("config.json", bundleConfig(*device, *samples, *interval, *timeout, now)); err != nil {
+		return err
+	}
+
+	if err := b.addJSON("status-parsed.json", parsed); err != nil {
+		return err
+	}
+
+	for i, e := range rec.exchanges {
+		if len(e.body) > 0 {
+			b.add(fmt.Sprintf("status/%03d.json", i), e.body)
+		}
+
+		b.add(fmt.Sprintf("exchanges/%03d.txt", i), e.dump)
+	}
+
+	if err := b.write(*output); err != nil {
+		return err
+	}
+
+	fmt.Println(*output)
+
+	return nil
+}
+
+type sample struct {
+	Time   time.Time            `json:"time"`
+	Status *velair.DeviceStatus `json:"status,omitempty"`
+	Error  string               `json:"error,omitempty"`
+}
+
+func bundleConfig(device string, samples int, interval, timeout time.Duration, now time.Time) map[string]any {
+	cfg := map[string]any{
+		"device":   redactURL(device),
+		"samples":  samples,
+		"interval": interval.String(),
+		"timeout":  timeout.String(),
+		"created":  now.UTC(),
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		cfg["go_version"] = info.GoVersion
+		cfg["version"] = info.Main.Version
+	}
+
+	return cfg
+}
+
+func redactURL(in string) string {
+	u, err := url.Parse(in)
+	if err != nil {
+		return "unparseable URL"
+	}
+
+	return u.Redacted()
+}
+
+type exchange struct {
+	dump []byte
+	body []byte
+}
+
+// recordingDoer wraps a Doer and keeps a dump of every request and response.
+type recordingDoer struct {
+	doer      velair.Doer
+	mu        sync.Mutex
+	exchanges []exchange
+}
+
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+func (r *recordingDoer) Do(req *http.Request) (*http.Response, error) {
+	var buf bytes.Buffer
+
+	dumpReq := req.Clone(req.Context())
+	dumpReq.URL.User = nil
+
+	for _, h := range redactedHeaders {
+		if dumpReq.Header.Get(h) != "" {
+			dumpReq.Header.Set(h, "REDACTED")
+		}
+	}
+
+	if d, err := httputil.DumpRequestOut(dumpReq, false); err == nil {
+		buf.Write(d)
+	}
+
+	resp, err := r.doer.Do(req)
+
+	e := exchange{}
+
+	if err != nil {
+		fmt.Fprintf(&buf, "\n\nerror: %v\n", err)
+	} else {
+		// read the body so it can be stored separately and replayed to the client.
+		body, readErr := io.ReadAll(resp.Body)
+		// nolint: errcheck
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		// redact a copy so the client still sees the real response.
+		dumpResp := *resp
+		dumpResp.Header = resp.Header.Clone()
+
+		for _, h := range redactedHeaders {
+			if dumpResp.Header.Get(h) != "" {
+				dumpResp.Header.Set(h, "REDACTED")
+			}
+		}
+
+		if d, dumpErr := httputil.DumpResponse(&dumpResp, false); dumpErr == nil {
+			buf.WriteString("\n")
+			buf.Write(d)
+		}
+
+		buf.Write(body)
+
+		if readErr != nil {
+			fmt.Fprintf(&buf, "\n\nerror reading body: %v\n", readErr)
+		}
+
+		if strings.HasSuffix(req.URL.Path, "/status") {
+			e.body = body
+		}
+	}
+
+	e.dump = buf.Bytes()
+
+	r.mu.Lock()
+	r.exchanges = append(r.exchanges, e)
+	r.mu.Unlock()
+
+	return resp, err
+}
+
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+type bundle struct {
+	files []bundleFile
+}
+
+func newBundle() *bundle {
+	return &bundle{}
+}
+
+func (b *bundle) add(name string, data []byte) {
+	b.files = append(b.files, bundleFile{name: name, data: data})
+}
+
+func (b *bundle) addJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	b.add(name, append(data, '\n'))
+
+	return nil
+}
+
+func (b *bundle) write(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	// nolint: errcheck
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	now := time.Now()
+
+	for _, file := range b.files {
+		hdr := &tar.Header{
+			Name:    "velair-support/" + file.name,
+			Mode:    0o644,
+			Size:    int64(len(file.data)),
+			ModTime: now,
+		}
+
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if _, err := tw.Write(file.data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	if err := gz.Close(); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/bakins/velair"
+)
+
+func decode(args []string) error {
+	fs := flag.NewFlagSet("decode", flag.ContinueOnError)
+	watch := fs.Bool("watch", false, "decode a stream of payloads read from stdin")
+
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: velair decode [--watch] [file ...]\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *watch {
+		if fs.NArg() > 0 {
+			fs.Usage()
+			return errors.New("--watch reads from stdin and does not accept files")
+		}
+
+		return decodeStream(os.Stdin, os.Stdout)
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("no files to decode")
+	}
+
+	for i, name := range fs.Args() {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			fmt.Println()
+		}
+
+		fmt.Printf("== %s\n", name)
+
+		if err := decodePayload(data, os.Stdout); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// decodeStream decodes each JSON value read from r. Payloads that cannot be
+// decoded are reported to w without stopping, but invalid JSON ends the stream.
+func decodeStream(r io.Reader, w io.Writer) error {
+	dec := json.NewDecoder(r)
+
+	for i := 0; ; i++ {
+		var data json.RawMessage
+
+		if err := dec.Decode(&data); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+
+		if err := decodePayload(data, w); err != nil {
+			fmt.Fprintf(w, "error: %v\n", err)
+		}
+	}
+}
+
+// knownField describes a key in the status payload that velair understands.
+type knownField struct {
+	description string
+	format      func(json.RawMessage) string
+	// optional fields are not reported when missing.
+	optional bool
+}
+
+// statusFields are the keys of the status payload used by velair.ParseRawStatus.
+var statusFields = map[string]knownField{
+	"success":    {"request succeeded", formatNone, false},
+	"error":      {"error message", formatNone, true},
+	"RESULT.fs":  {"fan speed", formatInt(fanSpeed), false},
+	"RESULT.nm":  {"night mode", formatInt(onOff), false},
+	"RESULT.ps":  {"power", formatInt(onOff), false},
+	"RESULT.sp":  {"set point", formatInt(celsius), false},
+	"RESULT.t":   {"temperature", formatInt(celsius), false},
+	"RESULT.wm":  {"mode", formatInt(deviceMode), false},
+	"setup.name": {"name", formatNone, false},
+}
+
+func decodePayload(data []byte, w io.Writer) error {
+	fields := map[string]json.RawMessage{}
+
+	if err := flatten("", data, fields); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "known fields:")
+
+	var unknown []string
+
+	for _, k := range keys {
+		f, ok := statusFields[k]
+		if !ok {
+			unknown = append(unknown, k)
+			continue
+		}
+
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", k, compact(fields[k]), f.description, f.format(fields[k]))
+	}
+
+	if len(unknown) > 0 {
+		fmt.Fprintln(tw, "unrecognized fields:")
+
+		for _, k := range unknown {
+			fmt.Fprintf(tw, "  %s\t%s\n", k, compact(fields[k]))
+		}
+	}
+
+	var missing []string
+
+	for k, f := range statusFields {
+		if _, ok := fields[k]; !ok && !f.optional {
+			missing = append(missing, k)
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+
+		// ParseRawStatus treats missing fields as zero values.
+		fmt.Fprintln(tw, "missing known fields:")
+
+		for _, k := range missing {
+			fmt.Fprintf(tw, "  %s\t%s\n", k, statusFields[k].description)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	status, err := velair.ParseRawStatus(data)
+	if err != nil {
+		fmt.Fprintf(w, "ParseRawStatus: error: %v\n", err)
+		return nil
+	}
+
+	fmt.Fprintf(w, "ParseRawStatus: %+v\n", *status)
+
+	return nil
+}
+
+// flatten adds the leaves of the JSON object in data to out,
+// keyed by their dotted path. Non-object values and empty objects are leaves.
+func flatten(prefix string, data []byte, out map[string]json.RawMessage) error {
+	var obj map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &obj)
+
+	if prefix == "" {
+		switch {
+		case err != nil:
+			return fmt.Errorf("payload is not a JSON object: %w", err)
+		case obj == nil:
+			return errors.New("payload is not a JSON object: null")
+		}
+	}
+
+	if prefix != "" && (err != nil || len(obj) == 0) {
+		out[prefix] = data
+
+		return nil
+	}
+
+	for k, v := range obj {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		if err := flatten(key, v, out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func compact(data json.RawMessage) string {
+	var buf bytes.Buffer
+
+	if err := json.Compact(&buf, data); err != nil {
+		return string(data)
+	}
+
+	return buf.String()
+}
+
+func formatNone(json.RawMessage) string {
+	return ""
+}
+
+func formatInt(f func(int) string) func(json.RawMessage) string {
+	return func(data json.RawMessage) string {
+		var v int
+
+		if err := json.Unmarshal(data, &v); err != nil {
+			return "invalid: expected integer"
+		}
+
+		return f(v)
+	}
+}
+
+func fanSpeed(v int) string {
+	s, err := velair.FanSpeedFromInt(v)
+	if err != nil {
+		return "invalid: " + err.Error()
+	}
+
+	return s.String()
+}
+
+func deviceMode(v int) string {
+	m, err := velair.DeviceModeFromInt(v)
+	if err != nil {
+		return "invalid: " + err.Error()
+	}
+
+	return m.String()
+}
+
+func onOff(v int) string {
+	return strconv.FormatBool(v == 1)
+}
+
+func celsius(v int) string {
+	return strconv.Itoa(v) + " C"
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bakins/velair/velairtest"
+)
+
+func TestDecodePayload(t *testing.T) {
+	cooling, err := velairtest.LoadFixture("synthetic-cooling")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+		err     bool
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "known payload",
+			payload: string(cooling),
+			want: []string{
+				"RESULT.fs   2",
+				"fan speed",
+				"medium",
+				"ParseRawStatus: {Name:Cabin",
+			},
+			notWant: []string{
+				"unrecognized fields:",
+				"missing known fields:",
+			},
+		},
+		{
+			name:    "unknown and empty nested keys",
+			payload: `{"success":true,"RESULT":{"fs":1,"nm":0,"ps":1,"sp":20,"t":21,"wm":1,"xx":7},"setup":{},"net":{"wifi":{}}}`,
+			want: []string{
+				"unrecognized fields:",
+				"RESULT.xx  7",
+				"net.wifi   {}",
+				"setup      {}",
+				"missing known fields:",
+				"setup.name",
+			},
+		},
+		{
+			name:    "missing known key",
+			payload: `{"success":true,"RESULT":{"fs":1}}`,
+			want: []string{
+				"missing known fields:",
+				"RESULT.sp",
+				"RESULT.wm",
+				"setup.name",
+			},
+		},
+		{
+			name:    "null",
+			payload: `null`,
+			err:     true,
+		},
+		{
+			name:    "not an object",
+			payload: `42`,
+			err:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := decodePayload([]byte(tt.payload), &buf)
+			if tt.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+
+				if strings.Contains(err.Error(), "%!") {
+					t.Errorf("malformed error %q", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			out := buf.String()
+
+			for _, w := range tt.want {
+				if !strings.Contains(out, w) {
+					t.Errorf("output does not contain %q:\n%s", w, out)
+				}
+			}
+
+			for _, w := range tt.notWant {
+				if strings.Contains(out, w) {
+					t.Errorf("output contains %q:\n%s", w, out)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeStreamContinues(t *testing.T) {
+	var buf bytes.Buffer
+
+	in := strings.NewReader(`42 {"success":true,"RESULT":{"fs":3}}`)
+
+	if err := decodeStream(in, &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+
+	if !strings.Contains(out, "error: payload is not a JSON object") {
+		t.Errorf("expected error for first payload:\n%s", out)
+	}
+
+	if !strings.Contains(out, "high") {
+		t.Errorf("expected second payload to be decoded:\n%s", out)
+	}
+}
+
+func TestDecodeWatchRejectsFiles(t *testing.T) {
+	if err := decode([]string{"--watch", "status.json"}); err == nil {
+		t.Error("expected error")
+	}
+}
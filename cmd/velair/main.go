@@ -0,0 +1,56 @@
+// Command velair provides command line tools for Velair air conditioners.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{
+		name:  "decode",
+		usage: "decode captured status payloads field by field",
+		run:   decode,
+	},
+	{
+		name:  "support-bundle",
+		usage: "collect diagnostics from a unit into an archive",
+		run:   supportBundle,
+	},
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: velair <command> [flags]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", c.name, c.usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, c := range commands {
+		if c.name != os.Args[1] {
+			continue
+		}
+
+		if err := c.run(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "velair %s: %v\n", c.name, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	usage()
+	os.Exit(2)
+}
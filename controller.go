@@ -0,0 +1,19 @@
+package velair
+
+import (
+	"context"
+)
+
+// Controller is the set of operations supported by a Velair backend.
+// Client, which talks to the unit over the local HTTP API, implements Controller.
+// Code that only needs these operations should accept a Controller so it
+// can be used with other backends and fakes.
+type Controller interface {
+	GetStatus(ctx context.Context) (*DeviceStatus, error)
+	SetNightMode(ctx context.Context, enable bool) error
+	SetFanSpeed(ctx context.Context, speed FanSpeed) error
+	SetMode(ctx context.Context, mode DeviceMode) error
+	SetPoint(ctx context.Context, temperature int) error
+}
+
+var _ Controller = (*Client)(nil)
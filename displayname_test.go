@@ -0,0 +1,102 @@
+package velair_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bakins/velair"
+)
+
+func TestDisplayName(t *testing.T) {
+	unit, srv := newFakeUnit(t)
+
+	c, err := velair.New(
+		srv.URL,
+		velair.WithDisplayName("Forward Cabin"),
+		velair.WithLightStatus("/light", time.Hour),
+		velair.WithPreflightCheck(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(name string, status *velair.DeviceStatus, reported string) {
+		t.Helper()
+
+		if status.Name != "Forward Cabin" {
+			t.Errorf("%s: expected display name, got %q", name, status.Name)
+		}
+
+		if status.ReportedName != reported {
+			t.Errorf("%s: expected reported name %q, got %q", name, reported, status.ReportedName)
+		}
+	}
+
+	check("preflight", c.Preflight().Status, "Cabin")
+
+	ctx := context.Background()
+
+	// renamed from the panel.
+	unit.SetName("Guest")
+
+	status, err := c.GetStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check("full status", status, "Guest")
+
+	status, err = c.GetLightStatus(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the light status has no name, so the reported name is the cached one.
+	check("light status", status, "Guest")
+
+	if got := unit.Requests(); got[len(got)-1] != "GET /light" {
+		t.Errorf("expected light status request, got %v", got)
+	}
+}
+
+func TestReportedNameWithoutDisplayName(t *testing.T) {
+	_, srv := newFakeUnit(t)
+
+	c, err := velair.New(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := c.GetStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.Name != "Cabin" || status.ReportedName != "Cabin" {
+		t.Errorf("unexpected names %q/%q", status.Name, status.ReportedName)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	in := map[string]string{"deck": "lower"}
+
+	c, err := velair.New("http://unit.invalid", velair.WithMetadata(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in["deck"] = "upper"
+
+	got := c.Metadata()
+	if !reflect.DeepEqual(got, map[string]string{"deck": "lower"}) {
+		t.Errorf("unexpected metadata %v", got)
+	}
+
+	got["deck"] = "main"
+
+	if c.Metadata()["deck"] != "lower" {
+		t.Error("Metadata returned a shared map")
+	}
+}
@@ -0,0 +1,67 @@
+package velair_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bakins/velair"
+	"github.com/bakins/velair/velairtest"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type golden struct {
+	Status *velair.DeviceStatus `json:"status,omitempty"`
+	Error  string               `json:"error,omitempty"`
+}
+
+func TestFixtures(t *testing.T) {
+	names := velairtest.Fixtures()
+	if len(names) == 0 {
+		t.Fatal("no fixtures found")
+	}
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			data, err := velairtest.LoadFixture(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got golden
+
+			got.Status, err = velair.ParseRawStatus(data)
+			if err != nil {
+				got.Error = err.Error()
+			}
+
+			gotData, err := json.MarshalIndent(got, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotData = append(gotData, '\n')
+
+			goldenFile := filepath.Join("testdata", "golden", name+".json")
+
+			if *update {
+				if err := os.WriteFile(goldenFile, gotData, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(goldenFile)
+			if err != nil {
+				t.Fatalf("missing golden file, run with -update: %v", err)
+			}
+
+			if !bytes.Equal(want, gotData) {
+				t.Errorf("parsed fixture does not match %s\ngot:\n%s\nwant:\n%s", goldenFile, gotData, want)
+			}
+		})
+	}
+}
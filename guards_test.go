@@ -0,0 +1,82 @@
+package velair_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bakins/velair"
+)
+
+// failDoer fails the test if any request is made.
+type failDoer struct {
+	t *testing.T
+}
+
+func (d failDoer) Do(req *http.Request) (*http.Response, error) {
+	d.t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+	return nil, errors.New("unexpected request")
+}
+
+func TestReadOnly(t *testing.T) {
+	c, err := velair.New("http://unit.invalid", velair.WithReadOnly(), velair.WithDoer(failDoer{t: t}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	setters := map[string]func() error{
+		"night mode": func() error { return c.SetNightMode(ctx, true) },
+		"fan speed":  func() error { return c.SetFanSpeed(ctx, velair.FanSpeedLow) },
+		"mode":       func() error { return c.SetMode(ctx, velair.DeviceModeHeating) },
+		"set point":  func() error { return c.SetPoint(ctx, 20) },
+	}
+
+	for name, set := range setters {
+		t.Run(name, func(t *testing.T) {
+			if err := set(); !errors.Is(err, velair.ErrReadOnly) {
+				t.Errorf("expected ErrReadOnly, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSetPointRange(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := velair.New("http://unit.invalid", velair.WithSetPointRange(8, 30), velair.WithDoer(failDoer{t: t}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, temp := range []int{7, 31} {
+		if err := c.SetPoint(ctx, temp); !errors.Is(err, velair.ErrSetPointOutOfRange) {
+			t.Errorf("expected ErrSetPointOutOfRange for %d, got %v", temp, err)
+		}
+	}
+
+	unit, srv := newFakeUnit(t)
+
+	c, err = velair.New(srv.URL, velair.WithSetPointRange(8, 30))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, temp := range []int{8, 30} {
+		if err := c.SetPoint(ctx, temp); err != nil {
+			t.Errorf("unexpected error for %d: %v", temp, err)
+		}
+	}
+
+	if got := len(unit.Requests()); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+func TestSetPointRangeInvalid(t *testing.T) {
+	if _, err := velair.New("http://unit.invalid", velair.WithSetPointRange(30, 8)); err == nil {
+		t.Error("expected error for inverted range")
+	}
+}
@@ -0,0 +1,90 @@
+package velair_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bakins/velair"
+)
+
+func TestGetLightStatus(t *testing.T) {
+	unit, srv := newFakeUnit(t)
+
+	c, err := velair.New(srv.URL, velair.WithLightStatus("/light", time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// no full status yet, then twice within the period.
+	for range 3 {
+		getLightStatus(t, c)
+	}
+
+	want := []string{
+		"GET /api/v/1/status",
+		"GET /light",
+		"GET /light",
+	}
+
+	if got := unit.Requests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected requests %v", got)
+	}
+}
+
+func TestGetLightStatusExpired(t *testing.T) {
+	unit, srv := newFakeUnit(t)
+
+	c, err := velair.New(srv.URL, velair.WithLightStatus("/light", time.Nanosecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every full status has expired by the next call.
+	for range 2 {
+		time.Sleep(time.Millisecond)
+		getLightStatus(t, c)
+	}
+
+	want := []string{
+		"GET /api/v/1/status",
+		"GET /api/v/1/status",
+	}
+
+	if got := unit.Requests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected requests %v", got)
+	}
+}
+
+func getLightStatus(t *testing.T, c *velair.Client) {
+	t.Helper()
+
+	status, err := c.GetLightStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.Name != "Cabin" || status.ReportedName != "Cabin" {
+		t.Errorf("expected name from full status, got %q/%q", status.Name, status.ReportedName)
+	}
+}
+
+func TestWithLightStatusInvalid(t *testing.T) {
+	tests := map[string]struct {
+		path   string
+		period time.Duration
+	}{
+		"relative path":   {"light", time.Minute},
+		"zero period":     {"/light", 0},
+		"negative period": {"/light", -time.Minute},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := velair.New("http://unit.invalid", velair.WithLightStatus(tt.path, tt.period)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package velair
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PreflightTimeout is the maximum time New waits for the preflight check.
+const PreflightTimeout = 10 * time.Second
+
+// PreflightResult is the result of the check run by WithPreflightCheck.
+type PreflightResult struct {
+	// StatusURL is the status endpoint that answered, with any password redacted.
+	StatusURL string
+	// Latency is the round trip time of the status request.
+	Latency time.Duration
+	// Status is the status reported by the unit during the check.
+	Status *DeviceStatus
+	// CheckedAt is when the check completed.
+	CheckedAt time.Time
+}
+
+// WithPreflightCheck causes New to verify that the unit is reachable and
+// answers the Velair status API, failing with a descriptive error otherwise.
+// The result is available from Client.Preflight.
+// The check is bounded by PreflightTimeout.
+func WithPreflightCheck() ClientOption {
+	return clientOptionFunc(func(c *Client) error {
+		c.preflightCheck = true
+		return nil
+	})
+}
+
+// Preflight returns the result of the check run by WithPreflightCheck,
+// or nil if the client was not created with that option.
+func (c *Client) Preflight() *PreflightResult {
+	return c.preflight
+}
+
+func (c *Client) runPreflight(ctx context.Context) (*PreflightResult, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("preflight: base URL %q must start with http:// or https://", u.Redacted())
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("preflight: base URL %q has no host", u.Redacted())
+	}
+
+	start := time.Now()
+
+	status, err := c.getStatus(ctx, statusPath)
+	if err != nil {
+		var statusErr *httpStatusError
+		var parseErr *parseError
+
+		switch {
+		case errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound:
+			return nil, fmt.Errorf("preflight: %s does not serve the Velair API (HTTP 404 for %s), check the address points at the unit", u.Redacted(), statusPath)
+		case errors.As(err, &statusErr):
+			return nil, fmt.Errorf("preflight: %w from %s", err, u.Redacted())
+		case errors.As(err, &parseErr):
+			return nil, fmt.Errorf("preflight: response from %s is not a valid Velair status: %w", u.Redacted(), err)
+		default:
+			return nil, fmt.Errorf("preflight: unable to reach unit at %s, check the address and that the unit is on the network: %w", u.Redacted(), err)
+		}
+	}
+
+	return &PreflightResult{
+		StatusURL: u.Redacted() + statusPath,
+		Latency:   time.Since(start),
+		Status:    status,
+		CheckedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,121 @@
+package velair_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bakins/velair"
+)
+
+func TestPreflight(t *testing.T) {
+	unit, srv := newFakeUnit(t)
+
+	base := strings.Replace(srv.URL, "http://", "http://admin:secret@", 1)
+
+	c, err := velair.New(base, velair.WithPreflightCheck(), velair.WithLightStatus("/light", time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := c.Preflight()
+
+	if strings.Contains(result.StatusURL, "secret") {
+		t.Errorf("status URL leaks password %q", result.StatusURL)
+	}
+
+	if !strings.HasSuffix(result.StatusURL, "/api/v/1/status") {
+		t.Errorf("unexpected status URL %q", result.StatusURL)
+	}
+
+	if result.Status.Name != "Cabin" || result.CheckedAt.IsZero() {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	// the preflight status counts as the most recent full status.
+	if _, err := c.GetLightStatus(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"GET /api/v/1/status", "GET /light"}
+	if got := unit.Requests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected requests %v", got)
+	}
+}
+
+func TestPreflightErrors(t *testing.T) {
+	tests := map[string]struct {
+		handler http.HandlerFunc
+		want    string
+	}{
+		"not found": {
+			handler: http.NotFound,
+			want:    "does not serve the Velair API",
+		},
+		"server error": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			want: "unexpected HTTP status code 500",
+		},
+		"invalid status": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`<html></html>`))
+			},
+			want: "is not a valid Velair status",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+
+			_, err := velair.New(srv.URL, velair.WithPreflightCheck())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+}
+
+func TestPreflightBaseURLErrors(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := map[string]struct {
+		baseURL string
+		want    string
+	}{
+		"unreachable": {
+			baseURL: closed.URL,
+			want:    "unable to reach unit at " + closed.URL + ", check the address and that the unit is on the network",
+		},
+		"no scheme": {
+			baseURL: "unit.local",
+			want:    "must start with http:// or https://",
+		},
+		"non-http scheme": {
+			baseURL: "ftp://x",
+			want:    `base URL "ftp://x" must start with http:// or https://`,
+		},
+		"empty host": {
+			baseURL: "http://",
+			want:    `base URL "http:" has no host`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := velair.New(tt.baseURL, velair.WithPreflightCheck())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package velair
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SelfTestMaxLatency is the maximum round trip time for a status request
+// that SelfTest considers healthy.
+const SelfTestMaxLatency = 2 * time.Second
+
+// SelfTestRestoreTimeout bounds restoring the unit's original settings.
+// Restoring is not cancelled with the context passed to SelfTest, so the
+// unit is not left with modified settings if that context is done.
+const SelfTestRestoreTimeout = 10 * time.Second
+
+// SelfTestStep is the result of a single step of a self test.
+type SelfTestStep struct {
+	Name     string
+	Duration time.Duration
+	Err      error
+}
+
+// SelfTestReport is the result of SelfTest.
+type SelfTestReport struct {
+	Steps []SelfTestStep
+	// Latency is the round trip time of the initial status request.
+	Latency time.Duration
+}
+
+// Passed returns true if all steps succeeded.
+func (r *SelfTestReport) Passed() bool {
+	for _, s := range r.Steps {
+		if s.Err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Err returns the first failed step as an error, or nil if all steps passed.
+func (r *SelfTestReport) Err() error {
+	for _, s := range r.Steps {
+		if s.Err != nil {
+			return fmt.Errorf("self test step %s failed: %w", s.Name, s.Err)
+		}
+	}
+
+	return nil
+}
+
+// SelfTest runs a scripted sequence against the unit: it reads the status,
+// toggles night mode and restores it, verifying each change with a status
+// read, and checks the status round trip latency against SelfTestMaxLatency.
+// The returned report is always non-nil. Use Passed or Err to check the outcome.
+// On a client created with WithReadOnly the toggle step fails with ErrReadOnly
+// and the test stops, as nothing was sent to the unit.
+func (c *Client) SelfTest(ctx context.Context) *SelfTestReport {
+	report := &SelfTestReport{}
+
+	run := func(name string, f func() error) bool {
+		start := time.Now()
+		err := f()
+		report.Steps = append(report.Steps, SelfTestStep{
+			Name:     name,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+
+		return err == nil
+	}
+
+	var original *DeviceStatus
+
+	ok := run("status", func() error {
+		start := time.Now()
+		status, err := c.GetStatus(ctx)
+		report.Latency = time.Since(start)
+		original = status
+		return err
+	})
+	if !ok {
+		return report
+	}
+
+	run("latency", func() error {
+		if report.Latency > SelfTestMaxLatency {
+			return fmt.Errorf("status round trip %s exceeds %s", report.Latency, SelfTestMaxLatency)
+		}
+		return nil
+	})
+
+	verifyNightMode := func(ctx context.Context, want bool) error {
+		status, err := c.GetStatus(ctx)
+		if err != nil {
+			return err
+		}
+		if status.NightMode != want {
+			return fmt.Errorf("expected night mode %t but unit reports %t", want, status.NightMode)
+		}
+		return nil
+	}
+
+	ok = run("toggle night mode", func() error {
+		return c.SetNightMode(ctx, !original.NightMode)
+	})
+	switch {
+	case ok:
+		run("verify toggle", func() error {
+			return verifyNightMode(ctx, !original.NightMode)
+		})
+	case errors.Is(report.Steps[len(report.Steps)-1].Err, ErrReadOnly):
+		// nothing was sent, so there is nothing to restore.
+		return report
+	}
+
+	// always attempt to restore once the toggle was sent, even if it or
+	// verification failed or ctx is done, as the unit may have applied it.
+	restoreCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), SelfTestRestoreTimeout)
+	defer cancel()
+
+	ok = run("restore night mode", func() error {
+		return c.SetNightMode(restoreCtx, original.NightMode)
+	})
+	if !ok {
+		return report
+	}
+
+	run("verify restore", func() error {
+		return verifyNightMode(restoreCtx, original.NightMode)
+	})
+
+	return report
+}
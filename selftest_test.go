@@ -0,0 +1,210 @@
+package velair_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/bakins/velair"
+)
+
+func stepNames(report *velair.SelfTestReport) []string {
+	var names []string
+	for _, s := range report.Steps {
+		names = append(names, s.Name)
+	}
+
+	return names
+}
+
+var allSelfTestSteps = []string{
+	"status",
+	"latency",
+	"toggle night mode",
+	"verify toggle",
+	"restore night mode",
+	"verify restore",
+}
+
+func TestSelfTest(t *testing.T) {
+	unit, srv := newFakeUnit(t)
+
+	c, err := velair.New(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := c.SelfTest(context.Background())
+
+	if err := report.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := stepNames(report); !reflect.DeepEqual(got, allSelfTestSteps) {
+		t.Errorf("unexpected steps %v", got)
+	}
+
+	if unit.NightMode() {
+		t.Error("night mode was not restored")
+	}
+}
+
+func TestSelfTestRestoresAfterFailedVerify(t *testing.T) {
+	unit, srv := newFakeUnit(t)
+	unit.ignoreWrites = true
+
+	c, err := velair.New(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := c.SelfTest(context.Background())
+
+	if report.Passed() {
+		t.Fatal("expected self test to fail")
+	}
+
+	if got := stepNames(report); !reflect.DeepEqual(got, allSelfTestSteps) {
+		t.Fatalf("unexpected steps %v", got)
+	}
+
+	if report.Steps[3].Err == nil {
+		t.Error("expected verify toggle to fail")
+	}
+
+	if report.Steps[4].Err != nil {
+		t.Errorf("unexpected restore error: %v", report.Steps[4].Err)
+	}
+}
+
+// cancelAfterDoer cancels a context after the first request to path.
+type cancelAfterDoer struct {
+	path   string
+	cancel context.CancelFunc
+}
+
+func (d *cancelAfterDoer) Do(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if req.URL.Path == d.path {
+		d.cancel()
+	}
+
+	return resp, err
+}
+
+func TestSelfTestRestoresAfterCancel(t *testing.T) {
+	unit, srv := newFakeUnit(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	doer := &cancelAfterDoer{path: "/api/v/1/set/feature/night", cancel: cancel}
+
+	c, err := velair.New(srv.URL, velair.WithDoer(doer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := c.SelfTest(ctx)
+
+	if got := stepNames(report); !reflect.DeepEqual(got, allSelfTestSteps) {
+		t.Fatalf("unexpected steps %v", got)
+	}
+
+	if report.Steps[3].Err == nil {
+		t.Error("expected verify toggle to fail after cancel")
+	}
+
+	for _, s := range report.Steps[4:] {
+		if s.Err != nil {
+			t.Errorf("step %s failed: %v", s.Name, s.Err)
+		}
+	}
+
+	if unit.NightMode() {
+		t.Error("night mode was not restored")
+	}
+}
+
+// failAfterDoer sends requests to path and then reports an error,
+// as if the reply was lost after the unit applied the change.
+type failAfterDoer struct {
+	path string
+	once bool
+}
+
+func (d *failAfterDoer) Do(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultClient.Do(req)
+	if err == nil && req.URL.Path == d.path && !d.once {
+		d.once = true
+		// nolint: errcheck
+		resp.Body.Close()
+		return nil, errors.New("reply lost")
+	}
+
+	return resp, err
+}
+
+func TestSelfTestRestoresAfterFailedToggle(t *testing.T) {
+	unit, srv := newFakeUnit(t)
+
+	doer := &failAfterDoer{path: "/api/v/1/set/feature/night"}
+
+	c, err := velair.New(srv.URL, velair.WithDoer(doer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := c.SelfTest(context.Background())
+
+	want := []string{
+		"status",
+		"latency",
+		"toggle night mode",
+		"restore night mode",
+		"verify restore",
+	}
+
+	if got := stepNames(report); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected steps %v", got)
+	}
+
+	if report.Steps[2].Err == nil {
+		t.Error("expected toggle to fail")
+	}
+
+	for _, s := range report.Steps[3:] {
+		if s.Err != nil {
+			t.Errorf("step %s failed: %v", s.Name, s.Err)
+		}
+	}
+
+	if unit.NightMode() {
+		t.Error("night mode was not restored")
+	}
+}
+
+func TestSelfTestReadOnly(t *testing.T) {
+	unit, srv := newFakeUnit(t)
+
+	c, err := velair.New(srv.URL, velair.WithReadOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := c.SelfTest(context.Background())
+
+	if got := stepNames(report); !reflect.DeepEqual(got, allSelfTestSteps[:3]) {
+		t.Fatalf("unexpected steps %v", got)
+	}
+
+	if !errors.Is(report.Err(), velair.ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly, got %v", report.Err())
+	}
+
+	if got := len(unit.Requests()); got != 1 {
+		t.Errorf("expected only the status request, got %d", got)
+	}
+}
@@ -0,0 +1,59 @@
+package velair_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/bakins/velair"
+)
+
+func TestSetters(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		set  func(*velair.Client) error
+		path string
+	}{
+		{
+			name: "night mode",
+			set:  func(c *velair.Client) error { return c.SetNightMode(ctx, true) },
+			path: "POST /api/v/1/set/feature/night",
+		},
+		{
+			name: "fan speed",
+			set:  func(c *velair.Client) error { return c.SetFanSpeed(ctx, velair.FanSpeedHigh) },
+			path: "POST /api/v/1/set/fan",
+		},
+		{
+			name: "mode",
+			set:  func(c *velair.Client) error { return c.SetMode(ctx, velair.DeviceModeCooling) },
+			path: "POST /api/v/1/set/mode/cooling",
+		},
+		{
+			name: "set point",
+			set:  func(c *velair.Client) error { return c.SetPoint(ctx, 21) },
+			path: "POST /api/v/1/set/setpoint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, srv := newFakeUnit(t)
+
+			c, err := velair.New(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tt.set(c); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := unit.Requests(); !reflect.DeepEqual(got, []string{tt.path}) {
+				t.Errorf("unexpected requests %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package velair_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeUnit is an in-memory Velair unit served over HTTP.
+type fakeUnit struct {
+	mu       sync.Mutex
+	name     string
+	night    bool
+	setPoint int
+	// ignoreWrites causes writes to succeed without changing state.
+	ignoreWrites bool
+	requests     []string
+}
+
+func newFakeUnit(t *testing.T) (*fakeUnit, *httptest.Server) {
+	t.Helper()
+
+	u := &fakeUnit{name: "Cabin", setPoint: 22}
+	srv := httptest.NewServer(u)
+	t.Cleanup(srv.Close)
+
+	return u, srv
+}
+
+// Requests returns the method and path of every request received.
+func (u *fakeUnit) Requests() []string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return append([]string(nil), u.requests...)
+}
+
+// SetName changes the name reported by the unit, as if from its panel.
+func (u *fakeUnit) SetName(name string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.name = name
+}
+
+// NightMode returns the current night mode of the unit.
+func (u *fakeUnit) NightMode() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.night
+}
+
+func (u *fakeUnit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.requests = append(u.requests, r.Method+" "+r.URL.Path)
+
+	if r.Method == http.MethodGet {
+		u.writeStatus(w, r.URL.Path)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/api/v/1/set/feature/night":
+		if !u.ignoreWrites {
+			u.night = r.PostForm.Get("value") == "1"
+		}
+	case r.URL.Path == "/api/v/1/set/fan",
+		r.URL.Path == "/api/v/1/set/setpoint",
+		strings.HasPrefix(r.URL.Path, "/api/v/1/set/mode/"):
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	_, _ = w.Write([]byte(`{"success":true}`))
+}
+
+func (u *fakeUnit) writeStatus(w http.ResponseWriter, path string) {
+	result := map[string]int{
+		"fs": 1,
+		"ps": 1,
+		"sp": u.setPoint,
+		"t":  24,
+		"wm": 1,
+	}
+
+	if u.night {
+		result["nm"] = 1
+	}
+
+	status := map[string]any{
+		"success": true,
+		"RESULT":  result,
+	}
+
+	switch path {
+	case "/api/v/1/status":
+		status["setup"] = map[string]string{"name": u.name}
+	case "/light":
+	default:
+		http.NotFound(w, nil)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(status)
+}
@@ -12,14 +12,43 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Client is an HTTP interface for Velair air conditioners.
 type Client struct {
-	doer    Doer
-	baseURL string
+	doer     Doer
+	baseURL  string
+	readOnly bool
+
+	preflightCheck bool
+	preflight      *PreflightResult
+
+	displayName string
+	metadata    map[string]string
+
+	hasSetPointRange bool
+	minSetPoint      int
+	maxSetPoint      int
+
+	lightStatusPath  string
+	fullStatusPeriod time.Duration
+
+	// mu protects fields below
+	mu             sync.Mutex
+	lastFullStatus time.Time
+	lastName       string
 }
 
+// ErrReadOnly is returned by methods that change the unit's settings
+// when the client was created with WithReadOnly.
+var ErrReadOnly = errors.New("client is read-only")
+
+// ErrSetPointOutOfRange is returned by SetPoint when the temperature
+// is outside the range set with WithSetPointRange.
+var ErrSetPointOutOfRange = errors.New("set point out of range")
+
 // New creates a new Client
 func New(baseURL string, options ...ClientOption) (*Client, error) {
 	u, err := url.Parse(baseURL)
@@ -38,6 +67,20 @@ func New(baseURL string, options ...ClientOption) (*Client, error) {
 		}
 	}
 
+	if c.preflightCheck {
+		ctx, cancel := context.WithTimeout(context.Background(), PreflightTimeout)
+		defer cancel()
+
+		result, err := c.runPreflight(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.preflight = result
+		c.recordFullStatus(result.Status)
+		c.applyDisplayName(result.Status)
+	}
+
 	return c, nil
 }
 
@@ -60,6 +103,84 @@ func WithDoer(d Doer) ClientOption {
 	})
 }
 
+// WithReadOnly causes all methods that change the unit's settings
+// to return ErrReadOnly without contacting the unit.
+func WithReadOnly() ClientOption {
+	return clientOptionFunc(func(c *Client) error {
+		c.readOnly = true
+		return nil
+	})
+}
+
+// WithDisplayName sets a name that overrides the name reported by the unit,
+// which can be changed from the unit's panel.
+// The reported name is still available in DeviceStatus.ReportedName.
+func WithDisplayName(name string) ClientOption {
+	return clientOptionFunc(func(c *Client) error {
+		c.displayName = name
+		return nil
+	})
+}
+
+// WithMetadata sets user defined metadata, such as location, for the unit.
+// See Client.Metadata.
+func WithMetadata(metadata map[string]string) ClientOption {
+	return clientOptionFunc(func(c *Client) error {
+		c.metadata = make(map[string]string, len(metadata))
+		for k, v := range metadata {
+			c.metadata[k] = v
+		}
+		return nil
+	})
+}
+
+// Metadata returns a copy of the metadata set with WithMetadata.
+func (c *Client) Metadata() map[string]string {
+	out := make(map[string]string, len(c.metadata))
+	for k, v := range c.metadata {
+		out[k] = v
+	}
+
+	return out
+}
+
+// WithSetPointRange limits the temperatures, in C, that SetPoint will send to the unit.
+// SetPoint returns an error wrapping ErrSetPointOutOfRange for temperatures
+// below minimum or above maximum.
+func WithSetPointRange(minimum, maximum int) ClientOption {
+	return clientOptionFunc(func(c *Client) error {
+		if minimum > maximum {
+			return fmt.Errorf("invalid set point range %d-%d", minimum, maximum)
+		}
+
+		c.hasSetPointRange = true
+		c.minSetPoint = minimum
+		c.maxSetPoint = maximum
+		return nil
+	})
+}
+
+// WithLightStatus enables GetLightStatus to use the lightweight status endpoint
+// at path, which some firmware provides.
+// The lightweight status omits the setup fields, so GetLightStatus
+// uses the full status endpoint instead whenever the last full status
+// is older than fullStatusPeriod, which must be positive.
+func WithLightStatus(path string, fullStatusPeriod time.Duration) ClientOption {
+	return clientOptionFunc(func(c *Client) error {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("light status path %q must start with /", path)
+		}
+
+		if fullStatusPeriod <= 0 {
+			return fmt.Errorf("invalid full status period %s, must be positive", fullStatusPeriod)
+		}
+
+		c.lightStatusPath = path
+		c.fullStatusPeriod = fullStatusPeriod
+		return nil
+	})
+}
+
 // Doer allows replacing the http client
 // See https://pkg.go.dev/net/http#Client
 type Doer interface {
@@ -155,13 +276,16 @@ func (d DeviceMode) String() string {
 
 // DeviceStatus represents the current status of the air conditioning unit.
 type DeviceStatus struct {
-	Name        string
-	FanSpeed    FanSpeed
-	NightMode   bool
-	Power       bool
-	SetPoint    int // in Celsius
-	Temperature int // in Celsius
-	Mode        DeviceMode
+	// Name is the name set with WithDisplayName, otherwise the name reported by the unit.
+	Name string
+	// ReportedName is the name reported by the unit.
+	ReportedName string
+	FanSpeed     FanSpeed
+	NightMode    bool
+	Power        bool
+	SetPoint     int // in Celsius
+	Temperature  int // in Celsius
+	Mode         DeviceMode
 }
 
 type rawDeviceStatus struct {
@@ -180,12 +304,97 @@ type rawDeviceStatus struct {
 	} `json:"setup"`
 }
 
+const statusPath = "/api/v/1/status"
+
 // GetStatus gets the current status of the unit.
 func (c *Client) GetStatus(ctx context.Context) (*DeviceStatus, error) {
+	status, err := c.getStatus(ctx, statusPath)
+	if err != nil {
+		return nil, err
+	}
+
+	c.recordFullStatus(status)
+	c.applyDisplayName(status)
+
+	return status, nil
+}
+
+// recordFullStatus caches the setup fields used by GetLightStatus.
+func (c *Client) recordFullStatus(status *DeviceStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.lastFullStatus = time.Now()
+	c.lastName = status.ReportedName
+}
+
+// GetLightStatus gets the current status of the unit suitable for frequent polling.
+// When configured with WithLightStatus, the lightweight endpoint is used and
+// the setup fields, such as Name, are filled in from the most recent full status.
+// Otherwise, or when the full status is older than the configured period,
+// this is the same as GetStatus.
+func (c *Client) GetLightStatus(ctx context.Context) (*DeviceStatus, error) {
+	if c.lightStatusPath == "" {
+		return c.GetStatus(ctx)
+	}
+
+	c.mu.Lock()
+	needFull := c.lastFullStatus.IsZero() || time.Since(c.lastFullStatus) >= c.fullStatusPeriod
+	name := c.lastName
+	c.mu.Unlock()
+
+	if needFull {
+		return c.GetStatus(ctx)
+	}
+
+	status, err := c.getStatus(ctx, c.lightStatusPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.ReportedName == "" {
+		status.ReportedName = name
+		status.Name = name
+	}
+
+	c.applyDisplayName(status)
+
+	return status, nil
+}
+
+func (c *Client) applyDisplayName(status *DeviceStatus) {
+	if c.displayName != "" {
+		status.Name = c.displayName
+	}
+}
+
+// httpStatusError is returned for unexpected HTTP status codes.
+type httpStatusError struct {
+	code int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status code %d", e.code)
+}
+
+// parseError is returned when the response is not a valid status.
+type parseError struct {
+	err error
+}
+
+func (e *parseError) Error() string {
+	return e.err.Error()
+}
+
+func (e *parseError) Unwrap() error {
+	return e.err
+}
+
+func (c *Client) getStatus(ctx context.Context, path string) (*DeviceStatus, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodGet,
-		c.baseURL+"/api/v/1/status",
+		c.baseURL+path,
 		nil,
 	)
 	if err != nil {
@@ -201,7 +410,7 @@ func (c *Client) GetStatus(ctx context.Context) (*DeviceStatus, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected HTTP status code %d", resp.StatusCode)
+		return nil, &httpStatusError{code: resp.StatusCode}
 	}
 
 	data, err := io.ReadAll(resp.Body)
@@ -209,7 +418,12 @@ func (c *Client) GetStatus(ctx context.Context) (*DeviceStatus, error) {
 		return nil, err
 	}
 
-	return ParseRawStatus(data)
+	status, err := ParseRawStatus(data)
+	if err != nil {
+		return nil, &parseError{err: err}
+	}
+
+	return status, nil
 }
 
 // ParseRawStatus parses the raw status returned from the device
@@ -235,9 +449,10 @@ func ParseRawStatus(data []byte) (*DeviceStatus, error) {
 	}
 
 	status := DeviceStatus{
-		Name:        raw.Setup.Name,
-		SetPoint:    raw.Result.SetPoint,
-		Temperature: raw.Result.Temperature,
+		Name:         raw.Setup.Name,
+		ReportedName: raw.Setup.Name,
+		SetPoint:     raw.Result.SetPoint,
+		Temperature:  raw.Result.Temperature,
 	}
 
 	status.FanSpeed, err = FanSpeedFromInt(raw.Result.FanSpeed)
@@ -266,6 +481,10 @@ func boolToStrInt(in bool) string {
 
 // SetNightMode enable or disables night mode.
 func (c *Client) SetNightMode(ctx context.Context, enable bool) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
 	values := url.Values{}
 
 	values.Set("value", boolToStrInt(enable))
@@ -294,7 +513,7 @@ func (c *Client) SetNightMode(ctx context.Context, enable bool) error {
 		return fmt.Errorf("unexpected HTTP status code %d", resp.StatusCode)
 	}
 
-	ok, err := parseCommandResponse(req.Body)
+	ok, err := parseCommandResponse(resp.Body)
 	if !ok {
 		return fmt.Errorf("failed to parse response %w", err)
 	}
@@ -338,6 +557,10 @@ func parseCommandResponse(r io.Reader) (bool, error) {
 // SetFanSpeed sets the fan speed.
 // This may return success but the unit may not actually change the speed
 func (c *Client) SetFanSpeed(ctx context.Context, speed FanSpeed) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
 	values := url.Values{}
 
 	values.Set("value", strconv.Itoa(int(speed)))
@@ -366,7 +589,7 @@ func (c *Client) SetFanSpeed(ctx context.Context, speed FanSpeed) error {
 		return fmt.Errorf("unexpected HTTP status code %d", resp.StatusCode)
 	}
 
-	ok, err := parseCommandResponse(req.Body)
+	ok, err := parseCommandResponse(resp.Body)
 	if !ok {
 		return fmt.Errorf("failed to parse response %w", err)
 	}
@@ -378,6 +601,10 @@ func (c *Client) SetFanSpeed(ctx context.Context, speed FanSpeed) error {
 // This may return success but the unit may not actually change the mode.
 // My unit will return success for dehumidify but does not actuall support dehumidify.
 func (c *Client) SetMode(ctx context.Context, mode DeviceMode) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
@@ -400,7 +627,7 @@ func (c *Client) SetMode(ctx context.Context, mode DeviceMode) error {
 		return fmt.Errorf("unexpected HTTP status code %d", resp.StatusCode)
 	}
 
-	ok, err := parseCommandResponse(req.Body)
+	ok, err := parseCommandResponse(resp.Body)
 	if !ok {
 		return fmt.Errorf("failed to parse response %w", err)
 	}
@@ -410,6 +637,14 @@ func (c *Client) SetMode(ctx context.Context, mode DeviceMode) error {
 
 // SetPoint sets the target temperature in C.
 func (c *Client) SetPoint(ctx context.Context, temperature int) error {
+	if c.readOnly {
+		return ErrReadOnly
+	}
+
+	if c.hasSetPointRange && (temperature < c.minSetPoint || temperature > c.maxSetPoint) {
+		return fmt.Errorf("%w: %d is not within %d-%d", ErrSetPointOutOfRange, temperature, c.minSetPoint, c.maxSetPoint)
+	}
+
 	values := url.Values{}
 
 	values.Set("p_temp", strconv.Itoa(temperature))
@@ -436,7 +671,7 @@ func (c *Client) SetPoint(ctx context.Context, temperature int) error {
 		return fmt.Errorf("unexpected HTTP status code %d", resp.StatusCode)
 	}
 
-	ok, err := parseCommandResponse(req.Body)
+	ok, err := parseCommandResponse(resp.Body)
 	if !ok {
 		return fmt.Errorf("failed to parse response %w", err)
 	}
@@ -0,0 +1,39 @@
+// Package velairtest provides a corpus of Velair status payloads for use in tests.
+// The shipped payloads are synthetic until real captures are contributed.
+package velairtest
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// LoadFixture returns the raw status payload of the named fixture.
+// The name is the file name in the fixtures directory, with or without the .json extension.
+func LoadFixture(name string) ([]byte, error) {
+	if !strings.HasSuffix(name, ".json") {
+		name += ".json"
+	}
+
+	return fixtures.ReadFile(path.Join("fixtures", name))
+}
+
+// Fixtures returns the names of all fixtures, without the .json extension, in sorted order.
+func Fixtures() []string {
+	// the pattern is known to be valid, so the error can be ignored.
+	matches, _ := fs.Glob(fixtures, "fixtures/*.json")
+
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		names = append(names, strings.TrimSuffix(path.Base(m), ".json"))
+	}
+
+	sort.Strings(names)
+
+	return names
+}